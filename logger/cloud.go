@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// cloudTraceKey and cloudSpanKey are the well-known attrs [slogctx.WithTrace]
+// attaches to a context. [cloudHandler] promotes them to Cloud Logging's
+// top-level trace/span fields instead of emitting them as ordinary attrs.
+const (
+	cloudTraceKey = "trace"
+	cloudSpanKey  = "spanId"
+)
+
+// newCloudHandler returns a [slog.Handler] that emits JSON in the shape
+// expected by Google Cloud Logging: `severity`/`message`/`timestamp` in
+// place of slog's `level`/`msg`/`time`, a
+// `logging.googleapis.com/sourceLocation` object in place of `source`, and,
+// when the context carries a trace attached via [slogctx.WithTrace],
+// top-level `logging.googleapis.com/trace` (qualified with projectID) and
+// `logging.googleapis.com/spanId` fields in place of the plain
+// `trace`/`spanId` attrs.
+//
+// It is used for the "cloud" (alias "stackdriver") logger type.
+func newCloudHandler(output io.Writer, options *slog.HandlerOptions, projectID string) slog.Handler {
+	jsonOptions := &slog.HandlerOptions{ReplaceAttr: cloudReplaceAttr}
+	if options != nil {
+		jsonOptions.Level = options.Level
+		jsonOptions.AddSource = options.AddSource
+	}
+	return &cloudHandler{next: slog.NewJSONHandler(output, jsonOptions), projectID: projectID}
+}
+
+// cloudHandler wraps a [slog.Handler] (normally one created by
+// [slog.NewJSONHandler] with [cloudReplaceAttr]) and promotes the
+// `trace`/`spanId` attrs of a record, if present, to Cloud Logging's
+// top-level trace/span fields.
+type cloudHandler struct {
+	next      slog.Handler
+	projectID string
+}
+
+var _ slog.Handler = (*cloudHandler)(nil)
+
+func (h *cloudHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *cloudHandler) Handle(ctx context.Context, r slog.Record) error {
+	var trace, spanID string
+	var rest []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		switch a.Key {
+		case cloudTraceKey:
+			trace = a.Value.String()
+		case cloudSpanKey:
+			spanID = a.Value.String()
+		default:
+			rest = append(rest, a)
+		}
+		return true
+	})
+	if trace == "" && spanID == "" {
+		return h.next.Handle(ctx, r)
+	}
+
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r2.AddAttrs(rest...)
+	if trace != "" {
+		if h.projectID != "" {
+			trace = "projects/" + h.projectID + "/traces/" + trace
+		}
+		r2.AddAttrs(slog.String("logging.googleapis.com/trace", trace))
+	}
+	if spanID != "" {
+		r2.AddAttrs(slog.String("logging.googleapis.com/spanId", spanID))
+	}
+	return h.next.Handle(ctx, r2)
+}
+
+func (h *cloudHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &cloudHandler{next: h.next.WithAttrs(attrs), projectID: h.projectID}
+}
+
+func (h *cloudHandler) WithGroup(name string) slog.Handler {
+	return &cloudHandler{next: h.next.WithGroup(name), projectID: h.projectID}
+}
+
+// cloudReplaceAttr implements [slog.HandlerOptions.ReplaceAttr] for the
+// "cloud" logger type, renaming slog's well-known top-level keys to the
+// names Cloud Logging understands.
+func cloudReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	case slog.MessageKey:
+		a.Key = "message"
+	case slog.LevelKey:
+		a.Key = "severity"
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(cloudSeverity(level))
+		}
+	case slog.SourceKey:
+		a.Key = "logging.googleapis.com/sourceLocation"
+		if source, ok := a.Value.Any().(*slog.Source); ok {
+			a.Value = slog.GroupValue(
+				slog.String("file", source.File),
+				slog.Int("line", source.Line),
+				slog.String("function", source.Function),
+			)
+		}
+	}
+	return a
+}
+
+// cloudSeverity maps a [slog.Level] to the Cloud Logging severity string
+// closest to it. See
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#LogSeverity.
+func cloudSeverity(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError+8:
+		return "EMERGENCY"
+	case level >= slog.LevelError+4:
+		return "CRITICAL"
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
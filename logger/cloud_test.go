@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloudSeverity(t *testing.T) {
+	cases := []struct {
+		level slog.Level
+		want  string
+	}{
+		{slog.LevelDebug, "DEBUG"},
+		{slog.LevelInfo - 1, "DEBUG"},
+		{slog.LevelInfo, "INFO"},
+		{slog.LevelWarn - 1, "INFO"},
+		{slog.LevelWarn, "WARNING"},
+		{slog.LevelError - 1, "WARNING"},
+		{slog.LevelError, "ERROR"},
+		{slog.LevelError + 3, "ERROR"},
+		{slog.LevelError + 4, "CRITICAL"},
+		{slog.LevelError + 7, "CRITICAL"},
+		{slog.LevelError + 8, "EMERGENCY"},
+	}
+	for _, c := range cases {
+		if got := cloudSeverity(c.level); got != c.want {
+			t.Errorf("cloudSeverity(%v) = %q, want %q", c.level, got, c.want)
+		}
+	}
+}
+
+func TestCloudReplaceAttr(t *testing.T) {
+	t.Run("renames well-known top-level keys", func(t *testing.T) {
+		if got := cloudReplaceAttr(nil, slog.String(slog.MessageKey, "hi")); got.Key != "message" || got.Value.String() != "hi" {
+			t.Errorf("message: got %v", got)
+		}
+		if got := cloudReplaceAttr(nil, slog.Time(slog.TimeKey, time.Time{})); got.Key != "timestamp" {
+			t.Errorf("time: got %v", got)
+		}
+		if got := cloudReplaceAttr(nil, slog.Any(slog.LevelKey, slog.LevelWarn)); got.Key != "severity" || got.Value.String() != "WARNING" {
+			t.Errorf("level: got %v", got)
+		}
+
+		src := &slog.Source{File: "f.go", Line: 42, Function: "pkg.Fn"}
+		got := cloudReplaceAttr(nil, slog.Any(slog.SourceKey, src))
+		if got.Key != "logging.googleapis.com/sourceLocation" {
+			t.Fatalf("source: got key %q", got.Key)
+		}
+		want := map[string]string{"file": "f.go", "line": "42", "function": "pkg.Fn"}
+		for _, a := range got.Value.Group() {
+			if a.Value.String() != want[a.Key] {
+				t.Errorf("source.%s = %v, want %v", a.Key, a.Value, want[a.Key])
+			}
+		}
+	})
+
+	t.Run("leaves attrs inside groups alone", func(t *testing.T) {
+		a := slog.String(slog.MessageKey, "hi")
+		if got := cloudReplaceAttr([]string{"group"}, a); got.Key != a.Key || !got.Value.Equal(a.Value) {
+			t.Errorf("got %v, want unchanged %v", got, a)
+		}
+	})
+}
+
+func TestCloudHandlerTracePromotion(t *testing.T) {
+	t.Run("with projectID", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := slog.New(newCloudHandler(&buf, nil, "my-project"))
+		log.Info("msg", "trace", "abc123", "spanId", "span1")
+
+		m := decodeJSON(t, buf.Bytes())
+		if got := m["logging.googleapis.com/trace"]; got != "projects/my-project/traces/abc123" {
+			t.Errorf("trace = %v", got)
+		}
+		if got := m["logging.googleapis.com/spanId"]; got != "span1" {
+			t.Errorf("spanId = %v", got)
+		}
+		if _, ok := m["trace"]; ok {
+			t.Errorf("plain trace attr should have been promoted, not left behind")
+		}
+	})
+
+	t.Run("without projectID", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := slog.New(newCloudHandler(&buf, nil, ""))
+		log.Info("msg", "trace", "abc123")
+
+		m := decodeJSON(t, buf.Bytes())
+		if got := m["logging.googleapis.com/trace"]; got != "abc123" {
+			t.Errorf("trace = %v, want unqualified", got)
+		}
+	})
+
+	t.Run("no trace or spanId", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := slog.New(newCloudHandler(&buf, nil, "my-project"))
+		log.Info("msg", "other", "x")
+
+		m := decodeJSON(t, buf.Bytes())
+		if _, ok := m["logging.googleapis.com/trace"]; ok {
+			t.Errorf("trace field should be absent, got %v", m)
+		}
+		if got := m["other"]; got != "x" {
+			t.Errorf("other = %v", got)
+		}
+	})
+}
+
+func TestCloudHandlerAddSource(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newCloudHandler(&buf, &slog.HandlerOptions{AddSource: true}, ""))
+	log.Info("msg")
+
+	m := decodeJSON(t, buf.Bytes())
+	loc, ok := m["logging.googleapis.com/sourceLocation"].(map[string]any)
+	if !ok {
+		t.Fatalf("missing sourceLocation: %v", m)
+	}
+	if file, _ := loc["file"].(string); !strings.HasSuffix(file, "cloud_test.go") {
+		t.Errorf("file = %q, want suffix cloud_test.go", file)
+	}
+	if _, ok := loc["line"]; !ok {
+		t.Errorf("missing line")
+	}
+	if fn, _ := loc["function"].(string); !strings.Contains(fn, "TestCloudHandlerAddSource") {
+		t.Errorf("function = %q", fn)
+	}
+}
+
+func decodeJSON(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("decoding JSON: %v\n%s", err, data)
+	}
+	return m
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestSanitizeJournalField(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"hello", "HELLO"},
+		{"Hello-World", "HELLO_WORLD"},
+		{"already_OK", "ALREADY_OK"},
+		{"123abc", "_123ABC"},
+		{"", "FIELD"},
+		{"!@#", "___"},
+	}
+	for _, c := range cases {
+		if got := sanitizeJournalField(c.in); got != c.want {
+			t.Errorf("sanitizeJournalField(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestEncodeJournalField(t *testing.T) {
+	t.Run("single line", func(t *testing.T) {
+		var buf bytes.Buffer
+		encodeJournalField(&buf, "MESSAGE", "hello world")
+		if got, want := buf.String(), "MESSAGE=hello world\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("multiline", func(t *testing.T) {
+		var buf bytes.Buffer
+		value := "line one\nline two"
+		encodeJournalField(&buf, "MESSAGE", value)
+
+		const prefix = "MESSAGE\n"
+		if got := buf.String()[:len(prefix)]; got != prefix {
+			t.Fatalf("got prefix %q, want %q", got, prefix)
+		}
+		rest := buf.Bytes()[len(prefix):]
+		if got, want := len(rest), 8+len(value)+1; got != want {
+			t.Fatalf("framed length = %d, want %d", got, want)
+		}
+		if length := binary.LittleEndian.Uint64(rest[:8]); length != uint64(len(value)) {
+			t.Errorf("length prefix = %d, want %d", length, len(value))
+		}
+		if got := string(rest[8 : 8+len(value)]); got != value {
+			t.Errorf("value = %q, want %q", got, value)
+		}
+		if rest[len(rest)-1] != '\n' {
+			t.Errorf("missing trailing newline")
+		}
+	})
+}
+
+func TestAppendJournalAttr(t *testing.T) {
+	var fields [][2]string
+	appendJournalAttr(&fields, "", slog.Group("req",
+		slog.String("method", "GET"),
+		slog.Group("user", slog.Int("id", 7)),
+	))
+	appendJournalAttr(&fields, "", slog.String("top-level", "x"))
+
+	want := [][2]string{
+		{"REQ_METHOD", "GET"},
+		{"REQ_USER_ID", "7"},
+		{"TOP_LEVEL", "x"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields %v, want %d %v", len(fields), fields, len(want), want)
+	}
+	for i, f := range fields {
+		if f != want[i] {
+			t.Errorf("fields[%d] = %v, want %v", i, f, want[i])
+		}
+	}
+}
+
+func TestLevelToPriority(t *testing.T) {
+	cases := []struct {
+		level slog.Level
+		want  int
+	}{
+		{slog.LevelDebug, 7},
+		{slog.LevelInfo - 1, 7},
+		{slog.LevelInfo, 6},
+		{slog.LevelWarn - 1, 6},
+		{slog.LevelWarn, 4},
+		{slog.LevelError - 1, 4},
+		{slog.LevelError, 3},
+		{slog.LevelError + 8, 3},
+	}
+	for _, c := range cases {
+		if got := levelToPriority(c.level); got != c.want {
+			t.Errorf("levelToPriority(%v) = %d, want %d", c.level, got, c.want)
+		}
+	}
+}
+
+func TestAutoType(t *testing.T) {
+	t.Run("under systemd", func(t *testing.T) {
+		t.Setenv("JOURNAL_STREAM", "1:2")
+		if got := autoType(os.Stdout); got != "journal" {
+			t.Errorf("autoType = %q, want journal", got)
+		}
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		t.Setenv("JOURNAL_STREAM", "")
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		if got := autoType(w); got != "json" {
+			t.Errorf("autoType = %q, want json", got)
+		}
+	})
+
+	t.Run("terminal", func(t *testing.T) {
+		t.Setenv("JOURNAL_STREAM", "")
+		tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+		if err != nil {
+			t.Skipf("no controlling terminal available: %v", err)
+		}
+		defer tty.Close()
+		if got := autoType(tty); got != "tint" {
+			t.Errorf("autoType = %q, want tint", got)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// newJournalHandler is unavailable outside Linux: systemd-journald's native
+// protocol is only reachable through the Linux-only unix socket at
+// [journalSocketPath].
+func newJournalHandler(options *slog.HandlerOptions) (slog.Handler, error) {
+	return nil, fmt.Errorf("logger: journal logger type is not supported on %s", runtime.GOOS)
+}
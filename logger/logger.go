@@ -1,7 +1,7 @@
 // Package logger is a placeholder package for creating and using a project-specific
 // [slog.Logger] across all modules in a program.
 //
-// This package provides only two functions and will maintain this minimal API in the future:
+// This package keeps its core API intentionally minimal:
 //
 //   - [New]() (*[slog.Logger], error)
 //   - [NewName](name string) (*[slog.Logger], error)
@@ -11,6 +11,11 @@
 // [NewName] returns a [slog.Logger] created based on the value of an environment variable.
 // However, this functionality is specific to this package and may not be suitable for all programs.
 //
+// In addition, [Level] and [SetLevel] expose the [slog.LevelVar] backing the
+// logger returned by [New]/[NewName], so the verbosity of a running process can
+// be changed without restarting it. [LevelHandler] exposes the same control as
+// an [http.Handler] for programs that want to mount it on their own mux.
+//
 // To customize the logger for your project:
 //
 //  1. Create a new module with a custom `logger` package.
@@ -28,9 +33,11 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -40,6 +47,7 @@ import (
 	"github.com/goaux/funcname"
 	"github.com/goaux/slog/slogctx"
 	"github.com/goaux/stacktrace/v2"
+	"github.com/mattn/go-isatty"
 )
 
 // New returns the result of calling [NewName](<package-name-of-caller>).
@@ -71,6 +79,15 @@ func New() (*slog.Logger, error) {
 //
 //   - "json": calls [slog.NewJSONHandler]
 //   - "text": calls [slog.NewTextHandler]
+//   - "tint": colorized, human-friendly `key=value` output for terminals
+//   - "journal": writes entries directly to systemd-journald using its native
+//     protocol (Linux only; fails on other platforms)
+//   - "auto": picks "journal" when running under systemd (detected via the
+//     `JOURNAL_STREAM` environment variable), otherwise "tint" when output is
+//     a terminal, otherwise "json"
+//   - "cloud" (alias "stackdriver"): JSON in the shape Google Cloud Logging
+//     expects, with trace correlation; see `ProjectID` below and
+//     [slogctx.WithTrace]
 //   - "discard": creates a [slog.Logger] that discards all logs
 //
 // Default is json.
@@ -102,6 +119,24 @@ func New() (*slog.Logger, error) {
 //
 // Default is false.
 //
+// LevelVarAddr:
+//
+// An optional `host:port/path` value, e.g. "levelVarAddr=:6060/loglevel".
+// When set, newRoot starts an HTTP server listening on `host:port` and serves
+// [LevelHandler] at `path`, allowing the level to be read and changed via
+// GET/PUT requests. See [Level], [SetLevel], and [LevelHandler].
+//
+// Default is unset, meaning no HTTP server is started.
+//
+// ProjectID:
+//
+// Only used by the "cloud" type. The Google Cloud project ID used to
+// qualify the `logging.googleapis.com/trace` field promoted from a
+// context's trace attr (see [slogctx.WithTrace]).
+//
+// Default is the `GOOGLE_CLOUD_PROJECT` environment variable. If that is
+// also unset, the trace field is emitted unqualified.
+//
 // # Customization
 //
 // The name of the environment variable can be changed at compile time.
@@ -161,6 +196,98 @@ func newName(name string) (*slog.Logger, error) {
 // and creating a [slog.Logger] is executed at most once.
 var newRootOnce = sync.OnceValues(newRoot)
 
+// level is the [slog.LevelVar] backing the handler created by [newRoot].
+//
+// It is never nil. [newRoot] sets it from the `level` parameter; if newRoot
+// fails before parsing that parameter, it stays at [slog.LevelInfo]. [Level]
+// forces newRoot to have run (via [newRootOnce]) before reading it, so that
+// level is never observed from outside this package before newRoot has run.
+var level = new(slog.LevelVar)
+
+// Level returns the [slog.LevelVar] controlling the verbosity of the logger
+// returned by [New] and [NewName]. Calling [slog.LevelVar.Set] on the
+// returned value changes the level of every such logger immediately, without
+// requiring the process to be restarted. The "discard" and "default" logger
+// types do not use it, so it has no effect on them.
+func Level() *slog.LevelVar {
+	newRootOnce()
+	return level
+}
+
+// SetLevel sets the level of the logger returned by [New] and [NewName].
+//
+// It is equivalent to [Level]().Set(l).
+func SetLevel(l slog.Level) {
+	Level().Set(l)
+}
+
+// LevelHandler returns an [http.Handler] that exposes [Level] over HTTP.
+//
+// A GET request responds with the current level as text (e.g. "INFO").
+// A PUT request sets the level to the value of the request body, which may
+// be text accepted by [slog.Level.UnmarshalText] (e.g. "debug", "warn+2") or
+// a plain integer offset (e.g. "-4"), and responds with the resulting level.
+//
+// This is the same control used internally when the `levelVarAddr` URL
+// parameter is set; LevelHandler is exported for programs that want to mount
+// it on their own mux instead.
+func LevelHandler() http.Handler {
+	newRootOnce()
+	return levelHandler()
+}
+
+// levelHandler is the shared implementation behind [LevelHandler]. Unlike
+// LevelHandler, it does not force [newRootOnce] to have run, so it is safe to
+// call from within [newRoot] itself (see [startLevelVarServer]); it relies on
+// `level` already being populated by its caller.
+func levelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintln(w, level.Level())
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l, err := parseLevel(string(body))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level.Set(l)
+			fmt.Fprintln(w, level.Level())
+		default:
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// startLevelVarServer starts an HTTP server serving [levelHandler] at spec,
+// which must be in the form `host:port/path` as accepted by the
+// `levelVarAddr` URL parameter described in [NewName].
+//
+// It is called from [newRoot] after `level` has already been set, so it uses
+// levelHandler directly rather than [LevelHandler], which would otherwise
+// deadlock by re-entering [newRootOnce] while the initial call is still in
+// progress.
+func startLevelVarServer(spec string) error {
+	addr, path, ok := strings.Cut(spec, "/")
+	if !ok {
+		return fmt.Errorf("invalid levelVarAddr=`%s`, must be in the form `host:port/path`", spec)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/"+path, levelHandler())
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Fprintf(os.Stderr, "logger: levelVarAddr server on %q stopped: %v\n", addr, err)
+		}
+	}()
+	return nil
+}
+
 // newRoot parses the environment variable and returns a [slog.Logger] created based on the parsed information.
 func newRoot() (*slog.Logger, error) {
 	s := os.Getenv(envKey)
@@ -174,10 +301,22 @@ func newRoot() (*slog.Logger, error) {
 	}
 	name, values := u.Path, u.Query()
 
+	lvl, err := parseLevel(values.Get("level"))
+	if err != nil {
+		return nil, err
+	}
+	level.Set(lvl)
+
+	if addr := values.Get("levelVarAddr"); addr != "" {
+		if err := startLevelVarServer(addr); err != nil {
+			return nil, err
+		}
+	}
+
 	switch name {
 	case "":
 		name = "json"
-	case "json", "text":
+	case "json", "text", "tint", "journal", "auto", "cloud", "stackdriver":
 		// ok. go ahead.
 	case "discard":
 		return slog.New(discardHandler{}), nil
@@ -197,15 +336,55 @@ func newRoot() (*slog.Logger, error) {
 		return nil, err
 	}
 
+	if name == "auto" {
+		name = autoType(output)
+	}
+
 	switch name {
 	case "json":
 		return slog.New(slogctx.NewHandler(slog.NewJSONHandler(output, options))), nil
 	case "text":
 		return slog.New(slogctx.NewHandler(slog.NewTextHandler(output, options))), nil
+	case "tint":
+		return slog.New(slogctx.NewHandler(newTintHandler(output, options))), nil
+	case "journal":
+		h, err := newJournalHandler(options)
+		if err != nil {
+			return nil, err
+		}
+		return slog.New(slogctx.NewHandler(h)), nil
+	case "cloud", "stackdriver":
+		projectID := values.Get("projectID")
+		if projectID == "" {
+			projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		}
+		return slog.New(slogctx.NewHandler(newCloudHandler(output, options, projectID))), nil
 	}
 	return nil, fmt.Errorf("unknown logger=`%s`", name)
 }
 
+// autoType resolves the "auto" logger type to a concrete one: "journal" when
+// running under systemd (detected via the `JOURNAL_STREAM` environment
+// variable), "tint" when output is a terminal, and "json" otherwise.
+func autoType(output io.Writer) string {
+	if os.Getenv("JOURNAL_STREAM") != "" {
+		return "journal"
+	}
+	if isTerminal(output) {
+		return "tint"
+	}
+	return "json"
+}
+
+// isTerminal reports whether w is a terminal, i.e. an [*os.File] connected to a TTY.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
 func getOutput(values url.Values) (io.Writer, error) {
 	s := values.Get("output")
 	switch s {
@@ -222,9 +401,17 @@ func getOutput(values url.Values) (io.Writer, error) {
 	return nil, fmt.Errorf("unknown output=`%s`, must be a file descriptor or one of `stdout`, `stderr` or `discard`", s)
 }
 
+// discardHandler is a [slog.Handler] that discards every record, used for
+// the "discard" logger type.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
 func newHandlerOptions(values url.Values) (*slog.HandlerOptions, error) {
 	var addSource bool
-	var level slog.Level
 	if s := values.Get("addSource"); s != "" {
 		if v, err := strconv.ParseBool(s); err != nil {
 			return nil, fmt.Errorf("invalid addSource=`%s`, must be parsed as a boolean", s)
@@ -232,15 +419,27 @@ func newHandlerOptions(values url.Values) (*slog.HandlerOptions, error) {
 			addSource = v
 		}
 	}
-	if s := values.Get("level"); s != "" {
-		s = strings.ReplaceAll(s, " ", "+")
-		if err := level.UnmarshalText([]byte(s)); err != nil {
-			return nil, fmt.Errorf("invalid level=`%s`, e.g. `debug`, `warn`, `info` or `error`", s)
-		}
-	}
 	options := &slog.HandlerOptions{
 		AddSource: addSource,
 		Level:     level,
 	}
 	return options, nil
 }
+
+// parseLevel parses s as a [slog.Level]. s may be text accepted by
+// [slog.Level.UnmarshalText] (e.g. "debug", "warn+2", ignoring case), or a
+// plain integer offset (e.g. "-4"). An empty s parses as [slog.LevelInfo].
+func parseLevel(s string) (slog.Level, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return slog.Level(n), nil
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ReplaceAll(s, " ", "+"))); err != nil {
+		return 0, fmt.Errorf("invalid level=`%s`, e.g. `debug`, `warn`, `info` or `error`", s)
+	}
+	return l, nil
+}
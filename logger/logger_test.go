@@ -1,7 +1,12 @@
 package logger_test
 
 import (
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
 	"github.com/goaux/results"
 	"github.com/goaux/slog/logger"
@@ -19,3 +24,57 @@ func ExampleNewName() {
 	log.Info("guide", slog.Int("the meaning of life", 42))
 	// Output:
 }
+
+func ExampleSetLevel() {
+	before := logger.Level().Level()
+	defer logger.SetLevel(before)
+
+	logger.SetLevel(slog.LevelWarn)
+	fmt.Println(logger.Level().Level())
+	// Output:
+	// WARN
+}
+
+func TestLevelHandler(t *testing.T) {
+	before := logger.Level().Level()
+	defer logger.SetLevel(before)
+
+	h := logger.LevelHandler()
+
+	logger.SetLevel(slog.LevelWarn)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", w.Code)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != "WARN" {
+		t.Errorf("GET body = %q, want WARN", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("debug")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d", w.Code)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != "DEBUG" {
+		t.Errorf("PUT body = %q, want DEBUG", got)
+	}
+	if got := logger.Level().Level(); got != slog.LevelDebug {
+		t.Errorf("level after PUT = %v, want DEBUG", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("not-a-level")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT invalid-body status = %d, want 400", w.Code)
+	}
+	if got := logger.Level().Level(); got != slog.LevelDebug {
+		t.Errorf("level after invalid PUT = %v, want unchanged DEBUG", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE status = %d, want 405", w.Code)
+	}
+}
@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/lmittmann/tint"
+)
+
+// newTintHandler returns a [slog.Handler] that writes colorized,
+// human-friendly `key=value` lines, suited for interactive terminals.
+//
+// It is used for the "tint" logger type, and for the "auto" logger type when
+// output is a terminal.
+func newTintHandler(output io.Writer, options *slog.HandlerOptions) slog.Handler {
+	var level slog.Leveler
+	var addSource bool
+	if options != nil {
+		level = options.Level
+		addSource = options.AddSource
+	}
+	return tint.NewHandler(output, &tint.Options{
+		AddSource: addSource,
+		Level:     level,
+	})
+}
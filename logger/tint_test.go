@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewTintHandler(t *testing.T) {
+	t.Run("plumbs level", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := newTintHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+		if h.Enabled(context.Background(), slog.LevelInfo) {
+			t.Error("Info should be below the configured Warn level")
+		}
+		if !h.Enabled(context.Background(), slog.LevelWarn) {
+			t.Error("Warn should be enabled at the configured Warn level")
+		}
+	})
+
+	t.Run("plumbs addSource", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := newTintHandler(&buf, &slog.HandlerOptions{AddSource: true})
+		log := slog.New(h)
+		log.Info("msg")
+		if !strings.Contains(buf.String(), "tint_test.go") {
+			t.Errorf("expected source location in output, got %q", buf.String())
+		}
+	})
+
+	t.Run("nil options", func(t *testing.T) {
+		var buf bytes.Buffer
+		h := newTintHandler(&buf, nil)
+		log := slog.New(h)
+		log.Info("msg")
+		if !strings.Contains(buf.String(), "msg") {
+			t.Errorf("expected message in output, got %q", buf.String())
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package slogctx
+
+import "context"
+
+// ContextAttrFunc extracts logging attrs from a [context.Context].
+//
+// It follows the same argument rules as [slog.Logger.Log]: each returned
+// element is either an [slog.Attr], or a string key immediately followed by
+// its value.
+//
+// ContextAttrFunc is used to let integrations (tracing, request IDs, tenant
+// IDs, auth subjects, ...) contribute well-known attrs to every record
+// produced by a [Handler], without requiring callers to attach them to the
+// context via [With] at every entry point.
+type ContextAttrFunc func(ctx context.Context) []any
+
+// globalFuncs holds the funcs registered via [Register]. It is read by every
+// [Handler], regardless of how it was constructed.
+var globalFuncs []ContextAttrFunc
+
+// Register appends fn to the package-level registry of [ContextAttrFunc].
+// Every [Handler] evaluates the registry, in registration order, before its
+// own per-handler funcs (see [NewHandlerWithFuncs]).
+//
+// Register is typically called from an init function or during program
+// startup, before any [Handler] processes a record.
+func Register(fn ContextAttrFunc) {
+	globalFuncs = append(globalFuncs, fn)
+}
+
+// ResetFuncs clears the package-level registry of [ContextAttrFunc]
+// populated by [Register]. It does not affect per-handler funcs passed to
+// [NewHandlerWithFuncs].
+//
+// ResetFuncs is primarily intended for use in tests.
+func ResetFuncs() {
+	globalFuncs = nil
+}
+
+// callFuncs evaluates the globally registered funcs followed by handlerFuncs,
+// in that order, and returns the non-empty results.
+func callFuncs(ctx context.Context, handlerFuncs []ContextAttrFunc) [][]any {
+	var out [][]any
+	for _, fn := range globalFuncs {
+		if a := fn(ctx); len(a) > 0 {
+			out = append(out, a)
+		}
+	}
+	for _, fn := range handlerFuncs {
+		if a := fn(ctx); len(a) > 0 {
+			out = append(out, a)
+		}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package slogctx_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/goaux/slog/slogctx"
+)
+
+func ExampleRegister() {
+	slogctx.Register(func(ctx context.Context) []any {
+		return []any{"region", "us-east-1"}
+	})
+	defer slogctx.ResetFuncs()
+
+	ctx := slogctx.With(context.Background(), "user", "alice")
+
+	logger := slog.New(
+		slogctx.NewHandler(
+			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		),
+	)
+	logger.InfoContext(ctx, "User logged in")
+	// Output:
+	// level=INFO msg="User logged in" region=us-east-1 user=alice
+}
+
+// ExampleNewHandlerWithFuncs also documents the merge order: globally
+// registered funcs, then this handler's own funcs, then the slogctx.With
+// stack, then the record's own attrs last.
+func ExampleNewHandlerWithFuncs() {
+	slogctx.Register(func(ctx context.Context) []any {
+		return []any{"global", "G"}
+	})
+	defer slogctx.ResetFuncs()
+
+	ctx := slogctx.With(context.Background(), "with", "W")
+
+	logger := slog.New(
+		slogctx.NewHandlerWithFuncs(
+			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+			func(ctx context.Context) []any { return []any{"perHandler", "H"} },
+		),
+	)
+	logger.InfoContext(ctx, "ordering", "own", "O")
+	// Output:
+	// level=INFO msg=ordering global=G perHandler=H with=W own=O
+}
+
+func TestResetFuncs(t *testing.T) {
+	slogctx.ResetFuncs()
+	t.Cleanup(slogctx.ResetFuncs)
+
+	calls := 0
+	slogctx.Register(func(ctx context.Context) []any {
+		calls++
+		return nil
+	})
+
+	ctx := context.Background()
+	logger := slog.New(slogctx.NewHandler(discardHandler{}))
+
+	logger.InfoContext(ctx, "msg")
+	if calls != 1 {
+		t.Errorf("expected the registered func to be called once, got %d", calls)
+	}
+
+	slogctx.ResetFuncs()
+	logger.InfoContext(ctx, "msg")
+	if calls != 1 {
+		t.Errorf("expected ResetFuncs to clear the registry, got %d calls", calls)
+	}
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -13,8 +13,53 @@ import (
 // in the log records it processes. This allows for contextual logging where common
 // attributes can be defined once at a higher level and automatically included in
 // all subsequent logging calls.
+//
+// In addition, Handler evaluates [ContextAttrFunc] registered via [Register] and,
+// for handlers created with [NewHandlerWithFuncs], attrs provided at construction
+// time. This lets integrations (tracing, request IDs, tenant IDs, auth subjects)
+// contribute well-known attrs to every record without callers having to call
+// [With] at every entry point.
+//
+// For a single record, attrs are merged in the following order: attrs from the
+// globally registered funcs, then attrs from this handler's own funcs, then the
+// attrs attached to the context via [With] (innermost first), and finally the
+// record's own attrs (those passed to the logging call) last.
+//
+// Handler is aware of [slog.Logger.WithGroup]: context attrs attached via [With]
+// before a WithGroup call are emitted at the scope that was active at that
+// time, rather than always landing inside the innermost group. See [Handler.WithGroup].
 type Handler struct {
+	// pre is the handler state as of the last point before any WithGroup call
+	// in this Handler's lineage: the target used to emit attrs that were
+	// attached via [With] before that WithGroup call.
+	pre slog.Handler
+	// next is the current (possibly grouped) handler state, used for the
+	// record's own attrs and for attrs attached via [With] after the
+	// innermost WithGroup call.
 	next slog.Handler
+	// groups holds the names passed to WithGroup, outermost first.
+	groups []string
+	// thresholds[i] is the sequence number (see [With]) observed just before
+	// groups[i] was applied: a With-attached frame belongs inside groups[i]
+	// only if its seq is greater than thresholds[i].
+	thresholds []int64
+	// bound is the chain of attrs attached via [Handler.WithAttrs] after the
+	// first WithGroup call in this Handler's lineage. Attrs attached before
+	// the first WithGroup call are already baked into pre and next alike, so
+	// they don't need to be tracked separately here.
+	bound *boundAttrs
+	funcs []ContextAttrFunc
+}
+
+// boundAttrs is one frame of the attrs attached to a [Handler] via
+// [Handler.WithAttrs] after its first WithGroup call, linked to the frame it
+// was built from. It is tagged with a sequence number from the same counter
+// as [With], so [Handler.handleGrouped] can place it at the group depth that
+// was active when it was attached, the same way it places context attrs.
+type boundAttrs struct {
+	attrs []slog.Attr
+	seq   int64
+	next  *boundAttrs
 }
 
 var _ slog.Handler = (*Handler)(nil)
@@ -26,8 +71,17 @@ var _ slog.Handler = (*Handler)(nil)
 // context (via [With]) when processing log records. This allows for hierarchical
 // logging where common attributes can be defined once and automatically included
 // in all subsequent logging calls.
+//
+// It is equivalent to calling [NewHandlerWithFuncs](next).
 func NewHandler(next slog.Handler) *Handler {
-	return &Handler{next: next}
+	return &Handler{pre: next, next: next}
+}
+
+// NewHandlerWithFuncs is like [NewHandler] but additionally registers funcs as
+// per-handler [ContextAttrFunc]. These are evaluated on every call to Handle,
+// in addition to any func registered globally via [Register].
+func NewHandlerWithFuncs(next slog.Handler, funcs ...ContextAttrFunc) *Handler {
+	return &Handler{pre: next, next: next, funcs: funcs}
 }
 
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
@@ -35,21 +89,114 @@ func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
 }
 
 func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
-	attrs := getAttrs(ctx)
-	if len(attrs) == 0 {
+	w := getAttrs(ctx)
+	funcAttrs := callFuncs(ctx, h.funcs)
+	if w == nil && len(funcAttrs) == 0 {
 		return h.next.Handle(ctx, r)
 	}
-	r2 := r.Clone()
-	for i := len(attrs) - 1; i >= 0; i-- {
-		r2.Add(attrs[i]...)
+	if len(h.groups) == 0 {
+		r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+		for _, a := range funcAttrs {
+			r2.Add(a...)
+		}
+		for f := w; f != nil; f = f.parent {
+			r2.Add(f.args...)
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			r2.AddAttrs(a)
+			return true
+		})
+		return h.next.Handle(Reset(ctx), r2)
 	}
-	return h.next.Handle(Reset(ctx), r2)
+	return h.handleGrouped(ctx, r, w, funcAttrs)
+}
+
+// handleGrouped reconstructs, using [slog.Group] values added to a fresh
+// record dispatched through h.pre, the nesting that h.next's real WithGroup
+// chain would otherwise apply unconditionally to every attr. Each With-attached
+// frame in w and each WithAttrs-attached frame in h.bound is placed at the
+// group depth that was active when it was attached; funcAttrs and the
+// record's own attrs are placed at the innermost depth, alongside whatever
+// the caller logged.
+func (h *Handler) handleGrouped(ctx context.Context, r slog.Record, w *withArgs, funcAttrs [][]any) error {
+	depth := len(h.groups)
+	boundBuckets := make([][]any, depth+1)
+	withBuckets := make([][]any, depth+1)
+
+	var frames []*boundAttrs
+	for b := h.bound; b != nil; b = b.next {
+		frames = append(frames, b)
+	}
+	for i := len(frames) - 1; i >= 0; i-- {
+		b := frames[i]
+		d := seqDepth(b.seq, h.thresholds)
+		for _, a := range b.attrs {
+			boundBuckets[d] = append(boundBuckets[d], a)
+		}
+	}
+
+	for f := w; f != nil; f = f.parent {
+		d := seqDepth(f.seq, h.thresholds)
+		withBuckets[d] = append(withBuckets[d], f.args...)
+	}
+
+	args := append([]any{}, boundBuckets[depth]...)
+	for _, a := range funcAttrs {
+		args = append(args, a...)
+	}
+	args = append(args, withBuckets[depth]...)
+	r.Attrs(func(a slog.Attr) bool {
+		args = append(args, a)
+		return true
+	})
+
+	for d := depth - 1; d >= 0; d-- {
+		groupAttr := slog.Group(h.groups[d], args...)
+		args = append(append([]any{}, boundBuckets[d]...), withBuckets[d]...)
+		args = append(args, groupAttr)
+	}
+
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r2.Add(args...)
+	return h.pre.Handle(Reset(ctx), r2)
+}
+
+// seqDepth reports how many of thresholds seq is greater than. thresholds is
+// ascending, so this is the group depth seq was attached at: 0 means it was
+// attached before the first WithGroup call, len(thresholds) means it was
+// attached after the last one.
+func seqDepth(seq int64, thresholds []int64) int {
+	d := 0
+	for _, t := range thresholds {
+		if seq <= t {
+			break
+		}
+		d++
+	}
+	return d
 }
 
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &Handler{next: h.next.WithAttrs(attrs)}
+	if len(attrs) == 0 {
+		return h
+	}
+	next := h.next.WithAttrs(attrs)
+	if len(h.groups) == 0 {
+		// No group has been applied yet, so attrs added now are still
+		// visible at the (so far ungrouped) pre scope.
+		return &Handler{pre: next, next: next, funcs: h.funcs}
+	}
+	bound := &boundAttrs{attrs: attrs, seq: nextSeq(), next: h.bound}
+	return &Handler{pre: h.pre, next: next, groups: h.groups, thresholds: h.thresholds, bound: bound, funcs: h.funcs}
 }
 
+// WithGroup returns a [slog.Handler] whose subsequent records are nested
+// under name, exactly as [slog.Handler.WithGroup] promises. It additionally
+// records the current [With] sequence number, so that [Handler.Handle] can
+// later tell which context attrs were attached before this call (and so
+// belong outside name) from those attached after it (which belong inside).
 func (h *Handler) WithGroup(name string) slog.Handler {
-	return &Handler{next: h.next.WithGroup(name)}
+	groups := append(append([]string{}, h.groups...), name)
+	thresholds := append(append([]int64{}, h.thresholds...), currentSeq())
+	return &Handler{pre: h.pre, next: h.next.WithGroup(name), groups: groups, thresholds: thresholds, bound: h.bound, funcs: h.funcs}
 }
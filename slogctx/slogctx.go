@@ -7,11 +7,19 @@
 // The package is particularly useful in middleware and request processing scenarios where
 // you want to attach common attributes (like request ID, user ID, etc.) at a higher level
 // and have them automatically included in all logging calls further down the call stack.
+//
+// In addition to per-context attributes attached via [With], the package supports
+// [ContextAttrFunc]: functions that derive attrs from the context at log time,
+// registered globally via [Register] or per-handler via [NewHandlerWithFuncs].
+// These are useful for integrations (tracing, request IDs, tenant IDs, auth
+// subjects) that want to contribute well-known attrs without requiring callers
+// to call [With] at every entry point.
 package slogctx
 
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,6 +37,10 @@ import (
 //   - If an argument is an [slog.Attr], it is used as is.
 //   - If an argument is a string and this is not the last argument, the following argument is treated as the value and the two are combined into an [slog.Attr].
 //   - Otherwise, the argument is treated as a value with key "!BADKEY".
+//
+// Each call to With is tagged with a monotonically increasing sequence
+// number, which [Handler] uses to decide whether the resulting attrs were
+// attached before or after a [slog.Logger.WithGroup] call.
 func With(parent context.Context, args ...any) context.Context {
 	if parent == nil {
 		panic("cannot create context from nil parent")
@@ -36,32 +48,45 @@ func With(parent context.Context, args ...any) context.Context {
 	if len(args) == 0 {
 		return parent
 	}
+	prev, _ := parent.Value(withArgsKey{}).(*withArgs)
 	return context.WithValue(
 		parent,
 		withArgsKey{},
-		&withArgs{attrs: appendAttrs(getAttrs(parent), args)},
+		&withArgs{args: args, seq: nextSeq(), parent: prev},
 	)
 }
 
-func getAttrs(ctx context.Context) [][]any {
-	if v, ok := ctx.Value(withArgsKey{}).(*withArgs); ok {
-		return v.attrs
-	}
-	return nil
+// withArgsKey is the context key under which the current *withArgs frame is stored.
+type withArgsKey struct{}
+
+// withArgs is one frame of the attrs attached to a context via [With], linked
+// to the frame it was built from. The head of the chain is the most recently
+// attached frame.
+type withArgs struct {
+	args   []any
+	seq    int64
+	parent *withArgs
 }
 
-func appendAttrs(parent [][]any, args []any) [][]any {
-	n := len(parent)
-	a := make([][]any, n+1)
-	copy(a, parent)
-	a[n] = args
-	return a
+// seqCounter hands out the sequence numbers tagged onto each frame built by
+// [With] and [Reset], and by [Handler.WithAttrs] after a WithGroup call, so
+// that [Handler] can later tell attrs attached before a [slog.Logger.WithGroup]
+// call apart from attrs attached after it.
+var seqCounter atomic.Int64
+
+func nextSeq() int64 {
+	return seqCounter.Add(1)
 }
 
-type withArgsKey struct{}
+// currentSeq returns the sequence number most recently handed out by
+// seqCounter, across all contexts and handlers, without allocating a new one.
+func currentSeq() int64 {
+	return seqCounter.Load()
+}
 
-type withArgs struct {
-	attrs [][]any
+func getAttrs(ctx context.Context) *withArgs {
+	w, _ := ctx.Value(withArgsKey{}).(*withArgs)
+	return w
 }
 
 // Attrs returns a slice containing the provided args followed by any attributes
@@ -71,19 +96,14 @@ type withArgs struct {
 // The returned slice can be used directly with [slog.Logger] methods or as
 // arguments to [slog.Logger.With] to create a new logger with combined attributes.
 func Attrs(ctx context.Context, args ...any) []any {
-	if v, ok := ctx.Value(withArgsKey{}).(*withArgs); ok {
-		return argsToAttrs(v.attrs, args)
-	} else if len(args) > 0 {
-		return argsToAttrs(nil, args)
+	w := getAttrs(ctx)
+	if w == nil && len(args) == 0 {
+		return nil
 	}
-	return nil
-}
-
-func argsToAttrs(list [][]any, args []any) []any {
 	r := slog.NewRecord(time.Time{}, 0, "", 0)
 	r.Add(args...)
-	for i := len(list) - 1; i >= 0; i-- {
-		r.Add(list[i]...)
+	for f := w; f != nil; f = f.parent {
+		r.Add(f.args...)
 	}
 	var attrs []any
 	r.Attrs(func(a slog.Attr) bool {
@@ -98,12 +118,20 @@ func argsToAttrs(list [][]any, args []any) []any {
 // context. This is useful when you want to start fresh with a new set of
 // attributes while maintaining the parent context's other values.
 func Reset(parent context.Context, args ...any) context.Context {
-	return context.WithValue(parent, withArgsKey{}, &withArgs{attrs: toAttrs(args)})
+	if len(args) == 0 {
+		return context.WithValue(parent, withArgsKey{}, (*withArgs)(nil))
+	}
+	return context.WithValue(parent, withArgsKey{}, &withArgs{args: args, seq: nextSeq()})
 }
 
-func toAttrs(args []any) [][]any {
-	if len(args) > 0 {
-		return [][]any{args}
-	}
-	return nil
+// WithTrace attaches traceID and spanID to ctx under the well-known "trace"
+// and "spanId" keys. It is equivalent to With(ctx, "trace", traceID,
+// "spanId", spanID).
+//
+// These are the keys the "cloud" logger type in
+// [github.com/goaux/slog/logger] promotes to Cloud Logging's top-level
+// trace/span fields, so attaching them via WithTrace is enough to get
+// correctly-correlated logs on GCE/GKE without writing a custom handler.
+func WithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return With(ctx, "trace", traceID, "spanId", spanID)
 }
@@ -26,7 +26,7 @@ func Example() {
 	// Log with context - attributes will be automatically included
 	logger.InfoContext(ctx, "User logged in", "count", 7)
 	// Output:
-	// level=INFO msg="User logged in" count=7 state=good user=alice age=42
+	// level=INFO msg="User logged in" state=good user=alice age=42 count=7
 }
 
 func ExampleWith() {
@@ -49,7 +49,7 @@ func ExampleWith() {
 	logger2.InfoContext(ctx, "User logged in", "g", "G")
 	// Output:
 	// level=INFO msg="User logged in" g=G e=E f=7 c=C d=D a=A b=B
-	// level=INFO msg="User logged in" g=G e=E f=7 c=C d=D a=A b=B
+	// level=INFO msg="User logged in" e=E f=7 c=C d=D a=A b=B g=G
 }
 
 func ExampleAttrs() {
@@ -84,20 +84,55 @@ func ExampleReset() {
 		),
 	)
 
-	// When you use slog.Logger.WithGroup, the attributes you attach to the
-	// context are output within a group.
+	// Context attrs attached before WithGroup are emitted outside the group;
+	// only the record's own attrs, attached after, land inside it.
 	logger.
 		WithGroup("GROUP").
 		InfoContext(ctx, "User logged in", "count", 7)
 
-	// If it's not what you expected, you can use Attrs and Reset to change the result.
-	logger.
-		With(slogctx.Attrs(ctx)...).
-		WithGroup("GROUP").
-		InfoContext(slogctx.Reset(ctx), "User logged in", "count", 7)
+	// Reset hides a context's existing attrs, so a fresh set can replace them.
+	logger.InfoContext(slogctx.Reset(ctx, "fresh", "F"), "User logged in")
 	// Output:
-	// {"level":"INFO","msg":"User logged in","GROUP":{"count":7,"state":"good","user":"alice","age":42}}
 	// {"level":"INFO","msg":"User logged in","state":"good","user":"alice","age":42,"GROUP":{"count":7}}
+	// {"level":"INFO","msg":"User logged in","fresh":"F"}
+}
+
+// ExampleHandler_WithGroup shows that context attrs attached after a
+// [slog.Logger.WithGroup] call do land inside the group, unlike attrs
+// attached before it (see [ExampleReset]).
+func ExampleHandler_WithGroup() {
+	logger := slog.New(
+		slogctx.NewHandler(
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		),
+	)
+
+	ctx := slogctx.With(context.Background(), "before", "B")
+	grouped := logger.WithGroup("GROUP")
+	ctx = slogctx.With(ctx, "after", "A")
+
+	grouped.InfoContext(ctx, "User logged in")
+	// Output:
+	// {"level":"INFO","msg":"User logged in","before":"B","GROUP":{"after":"A"}}
+}
+
+// ExampleHandler_WithAttrs shows that ordinary [slog.Logger.With] attrs
+// attached after a [slog.Logger.WithGroup] call are still emitted, alongside
+// the group's context attrs and the record's own attrs, rather than being
+// dropped.
+func ExampleHandler_WithAttrs() {
+	logger := slog.New(
+		slogctx.NewHandler(
+			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: removeTime}),
+		),
+	)
+
+	grouped := logger.WithGroup("A").With("extra", "E")
+	ctx := slogctx.With(context.Background(), "ctxattr", "C")
+
+	grouped.InfoContext(ctx, "msg", "own", "O")
+	// Output:
+	// {"level":"INFO","msg":"msg","A":{"extra":"E","ctxattr":"C","own":"O"}}
 }
 
 func removeTime(groups []string, a slog.Attr) slog.Attr {
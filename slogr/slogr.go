@@ -0,0 +1,41 @@
+// Package slogr bridges [log/slog.Logger] and [logr.Logger], so that code
+// written against either logging ecosystem can interoperate with loggers
+// built by [github.com/goaux/slog/logger] or [github.com/goaux/slog/slogctx].
+//
+// [NewLogr] and [FromLogr] are thin wrappers around [logr.FromSlogHandler]
+// and [logr.ToSlogHandler]: V-levels map to [slog.Level] using the
+// convention slog.Level = -int(v) (so V(0) is Info, V(1) is Debug,
+// V(4) is Debug-4), and [logr.Logger.Error] calls map to [slog.LevelError]
+// with the error placed under the key "err".
+//
+// Because [logr.LogSink] methods do not take a [context.Context], attrs
+// attached via [slogctx.With] are only visible to a [logr.Logger] obtained
+// from [NewLogr] if they were already baked into the given [*slog.Logger]
+// (e.g. via [slog.Logger.With]) before the call. To have [slogctx] extract
+// attrs from a live context, log through the [*slog.Logger] returned by
+// [FromLogr] and its context-aware methods (e.g. [slog.Logger.InfoContext])
+// instead of through the [logr.Logger].
+package slogr
+
+import (
+	"log/slog"
+
+	"github.com/go-logr/logr"
+)
+
+// NewLogr returns a [logr.Logger] that writes through log's handler.
+//
+// [logr.Logger.WithValues] is equivalent to calling [slog.Logger.With] on
+// log: both simply add attrs to every subsequent record.
+func NewLogr(log *slog.Logger) logr.Logger {
+	return logr.FromSlogHandler(log.Handler())
+}
+
+// FromLogr returns a [*slog.Logger] that writes through l's underlying sink.
+//
+// It is the inverse of [NewLogr]: a [*slog.Logger] obtained from a
+// [logr.Logger] that was itself obtained from [NewLogr](log) behaves the
+// same as log.
+func FromLogr(l logr.Logger) *slog.Logger {
+	return slog.New(logr.ToSlogHandler(l))
+}
@@ -0,0 +1,66 @@
+package slogr_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/goaux/slog/slogr"
+)
+
+func buildLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{ReplaceAttr: removeTime}))
+}
+
+func removeTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestNewLogr_Info(t *testing.T) {
+	var buf bytes.Buffer
+	log := slogr.NewLogr(buildLogger(&buf))
+
+	// A kubebuilder/controller-runtime style API only knows logr.Logger.
+	log.WithValues("name", "widget").Info("reconciling")
+
+	out := buf.String()
+	if !strings.Contains(out, "msg=reconciling") {
+		t.Errorf("expected message in output, got %q", out)
+	}
+	if !strings.Contains(out, "name=widget") {
+		t.Errorf("expected WithValues attr in output, got %q", out)
+	}
+}
+
+func TestNewLogr_Error(t *testing.T) {
+	var buf bytes.Buffer
+	log := slogr.NewLogr(buildLogger(&buf))
+
+	log.Error(errors.New("boom"), "failed")
+
+	out := buf.String()
+	if !strings.Contains(out, "level=ERROR") {
+		t.Errorf("expected ERROR level in output, got %q", out)
+	}
+	if !strings.Contains(out, "err=boom") {
+		t.Errorf("expected err attr in output, got %q", out)
+	}
+}
+
+func TestFromLogr(t *testing.T) {
+	var buf bytes.Buffer
+	log := buildLogger(&buf)
+
+	// Round-tripping through the bridge behaves like the original logger.
+	back := slogr.FromLogr(slogr.NewLogr(log))
+	back.Info("hello")
+
+	if !strings.Contains(buf.String(), "msg=hello") {
+		t.Errorf("expected message in output, got %q", buf.String())
+	}
+}